@@ -0,0 +1,69 @@
+package create
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/justwatchcom/gopass/pkg/fsutil"
+	"github.com/justwatchcom/gopass/pkg/out"
+	"github.com/justwatchcom/gopass/pkg/store"
+	"github.com/justwatchcom/gopass/pkg/store/secret"
+)
+
+func init() {
+	RegisterType("Database URL", &dbURLType{})
+}
+
+// dbURLType implements TypeFactory for database connection strings. The
+// individual components are stored as structured fields and the DSN is
+// reassembled on save, so it's available e.g. via `gopass show -o url`.
+type dbURLType struct{ baseType }
+
+func (dbURLType) Announce(ctx context.Context) { out.Green(ctx, "Creating Database URL ...") }
+
+func (dbURLType) Fields(Values) []Field {
+	return []Field{
+		{Name: "name", Prompt: "Please enter a name for this database", Required: true},
+		{Name: "scheme", Prompt: "Please enter the scheme (e.g. postgres, mysql)", Default: "postgres", Required: true},
+		{Name: "username", Prompt: "Please enter the username"},
+		{Name: "password", Prompt: "Please enter the password", Secret: true},
+		{Name: "host", Prompt: "Please enter the host", Default: "localhost", Required: true},
+		{Name: "port", Prompt: "Please enter the port (optional)"},
+		{Name: "dbname", Prompt: "Please enter the database name"},
+	}
+}
+
+func (dbURLType) PasswordField() string { return "" }
+
+func (dbURLType) Path(v Values) string {
+	return fmt.Sprintf("databases/%s", fsutil.CleanFilename(v["name"]))
+}
+
+func (dbURLType) Build(ctx context.Context, v Values) (store.Secret, error) {
+	sec := secret.New(v["password"], "")
+	_ = sec.SetValue("scheme", v["scheme"])
+	_ = sec.SetValue("username", v["username"])
+	_ = sec.SetValue("host", v["host"])
+	_ = sec.SetValue("port", v["port"])
+	_ = sec.SetValue("dbname", v["dbname"])
+	_ = sec.SetValue("url", buildDSN(v))
+	return sec, nil
+}
+
+// buildDSN reassembles a DSN from the decomposed fields.
+func buildDSN(v Values) string {
+	host := v["host"]
+	if v["port"] != "" {
+		host = fmt.Sprintf("%s:%s", host, v["port"])
+	}
+	u := url.URL{
+		Scheme: v["scheme"],
+		Host:   host,
+		Path:   "/" + v["dbname"],
+	}
+	if v["username"] != "" {
+		u.User = url.UserPassword(v["username"], v["password"])
+	}
+	return u.String()
+}
@@ -0,0 +1,110 @@
+package create
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/justwatchcom/gopass/pkg/action"
+	"github.com/justwatchcom/gopass/pkg/action/create/importers"
+	"github.com/justwatchcom/gopass/pkg/cui"
+	"github.com/justwatchcom/gopass/pkg/out"
+	"github.com/justwatchcom/gopass/pkg/store/sub"
+	"github.com/justwatchcom/gopass/pkg/termio"
+	"github.com/urfave/cli"
+)
+
+// createImport asks interactively for a file or directory to import from
+// and hands off to runImport. The non-interactive equivalent is
+// `gopass create --import <path>`, handled directly in Create.
+func (s *creator) createImport(ctx context.Context, c *cli.Context) error {
+	path, err := termio.AskForString(ctx, "Please enter the path to the file or directory to import from", "")
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		return action.ExitError(ctx, action.ExitUnknown, nil, "Path must not be empty")
+	}
+	return s.runImport(ctx, c, path)
+}
+
+// runImport detects the import format for path (or asks the user to pick
+// one) and writes every secret it yields to the store, prompting for a
+// conflict resolution whenever a target path already exists.
+func (s *creator) runImport(ctx context.Context, c *cli.Context, path string) error {
+	imp := importers.Detect(path)
+	if imp == nil {
+		imp = s.chooseImporter(ctx)
+	}
+	if imp == nil {
+		return action.ExitError(ctx, action.ExitUnknown, nil, "could not detect the import format of '%s'", path)
+	}
+
+	out.Green(ctx, "Importing from %s (%s) ...", path, imp.Label())
+
+	items, err := imp.Iterate(ctx, path)
+	if err != nil {
+		return action.ExitError(ctx, action.ExitUnknown, err, "failed to read '%s': %s", path, err)
+	}
+
+	count := 0
+	for item := range items {
+		name, err := s.resolveImportConflict(ctx, c, item.Name)
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			continue
+		}
+		if err := s.store.Set(sub.WithReason(ctx, "Imported entry"), name, item.Secret); err != nil {
+			return action.ExitError(ctx, action.ExitEncrypt, err, "failed to set '%s': %s", name, err)
+		}
+		count++
+	}
+
+	out.Green(ctx, "Imported %d secret(s)", count)
+	return nil
+}
+
+// resolveImportConflict returns the path to write item under, "" if the
+// entry should be skipped, and asks the user to choose when name already
+// exists in the store. --force always overwrites, for CI use.
+func (s *creator) resolveImportConflict(ctx context.Context, c *cli.Context, name string) (string, error) {
+	if !s.store.Exists(ctx, name) {
+		return name, nil
+	}
+	if c.Bool("force") {
+		return name, nil
+	}
+
+	choice, err := termio.AskForString(ctx, fmt.Sprintf("'%s' already exists. [s]kip/[o]verwrite/[r]ename?", name), "s")
+	if err != nil {
+		return "", err
+	}
+	switch strings.ToLower(strings.TrimSpace(choice)) {
+	case "o", "overwrite":
+		return name, nil
+	case "r", "rename":
+		return termio.AskForString(ctx, "Please choose another path", name)
+	default:
+		return "", nil
+	}
+}
+
+// chooseImporter lets the user pick an import format when Detect could
+// not identify one automatically.
+func (s *creator) chooseImporter(ctx context.Context) importers.Importer {
+	all := importers.All()
+	acts := make(cui.Actions, 0, len(all))
+	for _, imp := range all {
+		acts = append(acts, cui.Action{Name: imp.Label()})
+	}
+	act, sel := cui.GetSelection(ctx, "Please select the format to import from", "<↑/↓> to change the selection, <→> to select, <ESC> to quit", acts.Selection())
+	if act != "default" && act != "show" {
+		return nil
+	}
+	if sel < 0 || sel >= len(all) {
+		return nil
+	}
+	return all[sel]
+}
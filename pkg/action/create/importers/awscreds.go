@@ -0,0 +1,64 @@
+package importers
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/justwatchcom/gopass/pkg/fsutil"
+	"github.com/justwatchcom/gopass/pkg/store/secret"
+	ini "gopkg.in/ini.v1"
+)
+
+func init() {
+	Register(awsCredentialsFile{})
+}
+
+// awsCredentialsFile imports a ~/.aws/credentials-style INI file, one
+// secret per profile, mapped to aws/iam/<profile>/<profile>.
+type awsCredentialsFile struct{}
+
+func (awsCredentialsFile) Label() string { return "AWS credentials file" }
+
+func (awsCredentialsFile) Detect(path string) bool {
+	if filepath.Base(path) != "credentials" {
+		return false
+	}
+	_, err := ini.Load(path)
+	return err == nil
+}
+
+func (awsCredentialsFile) Iterate(ctx context.Context, path string) (<-chan ImportedSecret, error) {
+	cfg, err := ini.Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan ImportedSecret)
+	go func() {
+		defer close(out)
+		for _, profile := range cfg.Sections() {
+			if profile.Name() == ini.DefaultSection {
+				continue
+			}
+			akid := profile.Key("aws_access_key_id").String()
+			sak := profile.Key("aws_secret_access_key").String()
+			if akid == "" && sak == "" {
+				continue
+			}
+
+			sec := secret.New(sak, "")
+			_ = sec.SetValue("accesskey", akid)
+			_ = sec.SetValue("profile", profile.Name())
+			name := fsutil.CleanFilename(profile.Name())
+			is := ImportedSecret{
+				Name:   fmt.Sprintf("aws/iam/%s/%s", name, name),
+				Secret: sec,
+			}
+			if !send(ctx, out, is) {
+				return
+			}
+		}
+	}()
+	return out, nil
+}
@@ -0,0 +1,271 @@
+package importers
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/justwatchcom/gopass/pkg/fsutil"
+	"github.com/justwatchcom/gopass/pkg/store/secret"
+)
+
+func init() {
+	Register(chromeCSV{})
+	Register(firefoxCSV{})
+	Register(lastpassCSV{})
+	Register(onePasswordCSV{})
+}
+
+// readCSV reads path in full and splits it into a header row and the
+// remaining data rows.
+func readCSV(path string) ([]string, [][]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil, fmt.Errorf("empty CSV file")
+	}
+	return rows[0], rows[1:], nil
+}
+
+// hasHeader reports whether header contains all of want, case-insensitively.
+func hasHeader(header []string, want ...string) bool {
+	have := map[string]bool{}
+	for _, h := range header {
+		have[strings.ToLower(strings.TrimSpace(h))] = true
+	}
+	for _, w := range want {
+		if !have[w] {
+			return false
+		}
+	}
+	return true
+}
+
+func colIndex(header []string, name string) int {
+	for i, h := range header {
+		if strings.EqualFold(strings.TrimSpace(h), name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func field(row []string, idx int) string {
+	if idx < 0 || idx >= len(row) {
+		return ""
+	}
+	return row[idx]
+}
+
+// chromeCSV imports Chrome's "name,url,username,password" password
+// export.
+type chromeCSV struct{}
+
+func (chromeCSV) Label() string { return "Chrome CSV export" }
+
+func (chromeCSV) Detect(path string) bool {
+	header, _, err := readCSV(path)
+	return err == nil && hasHeader(header, "name", "url", "username", "password")
+}
+
+func (chromeCSV) Iterate(ctx context.Context, path string) (<-chan ImportedSecret, error) {
+	header, rows, err := readCSV(path)
+	if err != nil {
+		return nil, err
+	}
+	nameIdx := colIndex(header, "name")
+	urlIdx := colIndex(header, "url")
+	userIdx := colIndex(header, "username")
+	pwIdx := colIndex(header, "password")
+
+	out := make(chan ImportedSecret)
+	go func() {
+		defer close(out)
+		for _, row := range rows {
+			name := field(row, nameIdx)
+			if name == "" {
+				name = extractCSVHostname(field(row, urlIdx))
+			}
+			sec := secret.New(field(row, pwIdx), "")
+			_ = sec.SetValue("url", field(row, urlIdx))
+			_ = sec.SetValue("username", field(row, userIdx))
+			is := ImportedSecret{
+				Name:   fmt.Sprintf("websites/%s/%s", fsutil.CleanFilename(name), fsutil.CleanFilename(field(row, userIdx))),
+				Secret: sec,
+			}
+			if !send(ctx, out, is) {
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// firefoxCSV imports Firefox's "url,username,password,httpRealm,
+// formActionOrigin,guid,timeCreated,timeLastUsed,timePasswordChanged"
+// logins export.
+type firefoxCSV struct{}
+
+func (firefoxCSV) Label() string { return "Firefox CSV export" }
+
+// Detect requires Firefox's full, distinctive column set rather than just
+// "url,username,password" - that subset is also present in LastPass's
+// export, and being tried first in registration order would otherwise
+// shadow it.
+func (firefoxCSV) Detect(path string) bool {
+	header, _, err := readCSV(path)
+	return err == nil && hasHeader(header, "url", "username", "password", "httprealm", "formactionorigin", "guid")
+}
+
+func (firefoxCSV) Iterate(ctx context.Context, path string) (<-chan ImportedSecret, error) {
+	header, rows, err := readCSV(path)
+	if err != nil {
+		return nil, err
+	}
+	urlIdx := colIndex(header, "url")
+	userIdx := colIndex(header, "username")
+	pwIdx := colIndex(header, "password")
+
+	out := make(chan ImportedSecret)
+	go func() {
+		defer close(out)
+		for _, row := range rows {
+			sec := secret.New(field(row, pwIdx), "")
+			_ = sec.SetValue("url", field(row, urlIdx))
+			_ = sec.SetValue("username", field(row, userIdx))
+			is := ImportedSecret{
+				Name:   fmt.Sprintf("websites/%s/%s", fsutil.CleanFilename(extractCSVHostname(field(row, urlIdx))), fsutil.CleanFilename(field(row, userIdx))),
+				Secret: sec,
+			}
+			if !send(ctx, out, is) {
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// lastpassCSV imports LastPass's "url,username,password,extra,name,
+// grouping,fav" vault export.
+type lastpassCSV struct{}
+
+func (lastpassCSV) Label() string { return "LastPass CSV export" }
+
+func (lastpassCSV) Detect(path string) bool {
+	header, _, err := readCSV(path)
+	return err == nil && hasHeader(header, "url", "username", "password", "name", "grouping")
+}
+
+func (lastpassCSV) Iterate(ctx context.Context, path string) (<-chan ImportedSecret, error) {
+	header, rows, err := readCSV(path)
+	if err != nil {
+		return nil, err
+	}
+	urlIdx := colIndex(header, "url")
+	userIdx := colIndex(header, "username")
+	pwIdx := colIndex(header, "password")
+	nameIdx := colIndex(header, "name")
+	extraIdx := colIndex(header, "extra")
+	groupIdx := colIndex(header, "grouping")
+
+	out := make(chan ImportedSecret)
+	go func() {
+		defer close(out)
+		for _, row := range rows {
+			name := field(row, nameIdx)
+			if name == "" {
+				name = extractCSVHostname(field(row, urlIdx))
+			}
+			if g := field(row, groupIdx); g != "" {
+				name = g + "/" + name
+			}
+			sec := secret.New(field(row, pwIdx), "")
+			_ = sec.SetValue("url", field(row, urlIdx))
+			_ = sec.SetValue("username", field(row, userIdx))
+			_ = sec.SetValue("comment", field(row, extraIdx))
+			is := ImportedSecret{
+				Name:   fsutil.CleanFilename(name) + "/" + fsutil.CleanFilename(field(row, userIdx)),
+				Secret: sec,
+			}
+			if !send(ctx, out, is) {
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// onePasswordCSV imports 1Password's CSV export. The legacy .1pif
+// format is out of scope here - export to CSV from 1Password first.
+type onePasswordCSV struct{}
+
+func (onePasswordCSV) Label() string { return "1Password CSV export" }
+
+func (onePasswordCSV) Detect(path string) bool {
+	header, _, err := readCSV(path)
+	return err == nil && hasHeader(header, "title", "website", "username", "password")
+}
+
+func (onePasswordCSV) Iterate(ctx context.Context, path string) (<-chan ImportedSecret, error) {
+	header, rows, err := readCSV(path)
+	if err != nil {
+		return nil, err
+	}
+	titleIdx := colIndex(header, "title")
+	urlIdx := colIndex(header, "website")
+	userIdx := colIndex(header, "username")
+	pwIdx := colIndex(header, "password")
+
+	out := make(chan ImportedSecret)
+	go func() {
+		defer close(out)
+		for _, row := range rows {
+			sec := secret.New(field(row, pwIdx), "")
+			_ = sec.SetValue("url", field(row, urlIdx))
+			_ = sec.SetValue("username", field(row, userIdx))
+			is := ImportedSecret{
+				Name:   fmt.Sprintf("websites/%s/%s", fsutil.CleanFilename(field(row, titleIdx)), fsutil.CleanFilename(field(row, userIdx))),
+				Secret: sec,
+			}
+			if !send(ctx, out, is) {
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// extractCSVHostname is a small, dependency-free hostname extractor for
+// importers - good enough for the vault exports handled here, which
+// rarely contain the exotic URLs extractHostname in the parent package
+// guards against.
+func extractCSVHostname(rawurl string) string {
+	u := strings.TrimPrefix(rawurl, "https://")
+	u = strings.TrimPrefix(u, "http://")
+	if i := strings.IndexAny(u, "/?#"); i >= 0 {
+		u = u[:i]
+	}
+	return u
+}
+
+// send delivers is on out, returning false if ctx was cancelled first.
+func send(ctx context.Context, out chan<- ImportedSecret, is ImportedSecret) bool {
+	select {
+	case out <- is:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
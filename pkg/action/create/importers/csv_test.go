@@ -0,0 +1,99 @@
+package importers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFixture(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "export.csv")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("WriteFile: %s", err)
+	}
+	return path
+}
+
+func collect(t *testing.T, imp Importer, path string) []ImportedSecret {
+	t.Helper()
+	ch, err := imp.Iterate(context.Background(), path)
+	if err != nil {
+		t.Fatalf("Iterate: %s", err)
+	}
+	var got []ImportedSecret
+	for is := range ch {
+		got = append(got, is)
+	}
+	return got
+}
+
+func TestChromeCSV(t *testing.T) {
+	path := writeFixture(t, "name,url,username,password\nExample,https://example.com/login,alice,hunter2\n")
+
+	imp := chromeCSV{}
+	if !imp.Detect(path) {
+		t.Fatal("Detect() = false, want true")
+	}
+
+	secrets := collect(t, imp, path)
+	if len(secrets) != 1 {
+		t.Fatalf("got %d secrets, want 1", len(secrets))
+	}
+	if want := "websites/Example/alice"; secrets[0].Name != want {
+		t.Errorf("Name = %q, want %q", secrets[0].Name, want)
+	}
+}
+
+func TestFirefoxCSV(t *testing.T) {
+	path := writeFixture(t, "url,username,password,httpRealm,formActionOrigin,guid,timeCreated,timeLastUsed,timePasswordChanged\nhttps://example.com/,bob,s3cr3t,,,,,,\n")
+
+	imp := firefoxCSV{}
+	if !imp.Detect(path) {
+		t.Fatal("Detect() = false, want true")
+	}
+
+	secrets := collect(t, imp, path)
+	if len(secrets) != 1 {
+		t.Fatalf("got %d secrets, want 1", len(secrets))
+	}
+	if want := "websites/example.com/bob"; secrets[0].Name != want {
+		t.Errorf("Name = %q, want %q", secrets[0].Name, want)
+	}
+}
+
+func TestLastPassCSVNotDetectedAsFirefox(t *testing.T) {
+	path := writeFixture(t, "url,username,password,extra,name,grouping,fav\nhttps://example.com/,carol,s3cr3t,,Example,,0\n")
+
+	ff, lp := firefoxCSV{}, lastpassCSV{}
+	if ff.Detect(path) {
+		t.Error("firefoxCSV.Detect() = true for a LastPass export, want false (collision with the more specific format)")
+	}
+	if !lp.Detect(path) {
+		t.Error("lastpassCSV.Detect() = false for its own export, want true")
+	}
+}
+
+func TestCSVDetectRejectsWrongHeader(t *testing.T) {
+	path := writeFixture(t, "foo,bar\n1,2\n")
+
+	for _, imp := range []Importer{chromeCSV{}, firefoxCSV{}, lastpassCSV{}, onePasswordCSV{}} {
+		if imp.Detect(path) {
+			t.Errorf("%s: Detect() = true for a file with an unrelated header", imp.Label())
+		}
+	}
+}
+
+func TestExtractCSVHostname(t *testing.T) {
+	for _, tc := range []struct{ in, want string }{
+		{"https://example.com/login?x=1", "example.com"},
+		{"http://example.com/", "example.com"},
+		{"example.com", "example.com"},
+		{"", ""},
+	} {
+		if got := extractCSVHostname(tc.in); got != tc.want {
+			t.Errorf("extractCSVHostname(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package importers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/justwatchcom/gopass/pkg/fsutil"
+	"github.com/justwatchcom/gopass/pkg/store/secret"
+)
+
+func init() {
+	Register(gcpDirectory{})
+}
+
+// gcpDirectory batch-imports a directory of GCP service account JSON
+// files, one secret per *.json file, reusing the same gcp/iam/<project>/
+// <username> path layout as the GCP Service Account create wizard type.
+type gcpDirectory struct{}
+
+func (gcpDirectory) Label() string { return "GCP service account directory" }
+
+func (gcpDirectory) Detect(path string) bool {
+	fi, err := os.Stat(path)
+	if err != nil || !fi.IsDir() {
+		return false
+	}
+	matches, _ := filepath.Glob(filepath.Join(path, "*.json"))
+	return len(matches) > 0
+}
+
+func (gcpDirectory) Iterate(ctx context.Context, path string) (<-chan ImportedSecret, error) {
+	matches, err := filepath.Glob(filepath.Join(path, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan ImportedSecret)
+	go func() {
+		defer close(out)
+		for _, fn := range matches {
+			buf, err := ioutil.ReadFile(fn)
+			if err != nil {
+				continue
+			}
+			username, project, err := gcpServiceAccountInfo(buf)
+			if err != nil {
+				continue
+			}
+			is := ImportedSecret{
+				Name:   fmt.Sprintf("gcp/iam/%s/%s", fsutil.CleanFilename(project), fsutil.CleanFilename(username)),
+				Secret: secret.New("", string(buf)),
+			}
+			if !send(ctx, out, is) {
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// gcpServiceAccountInfo extracts the account name and project from a GCP
+// service account JSON blob.
+func gcpServiceAccountInfo(buf []byte) (string, string, error) {
+	var m map[string]string
+	if err := json.Unmarshal(buf, &m); err != nil {
+		return "", "", err
+	}
+	p := strings.Split(m["client_email"], "@")
+	if len(p) < 2 {
+		return "", "", fmt.Errorf("client_email contains no email")
+	}
+	username := p[0]
+	p = strings.Split(p[1], ".")
+	if len(p) < 1 {
+		return username, "", fmt.Errorf("hostname contains not enough separators")
+	}
+	return username, p[0], nil
+}
@@ -0,0 +1,54 @@
+// Package importers implements bulk-loading of secrets from external
+// password managers and credential files, for the create wizard's Import
+// action.
+package importers
+
+import (
+	"context"
+
+	"github.com/justwatchcom/gopass/pkg/store"
+)
+
+// ImportedSecret is one entry produced by an Importer.
+type ImportedSecret struct {
+	// Name is the suggested store path for this secret.
+	Name string
+	// Secret is the decoded secret ready to be written to the store.
+	Secret store.Secret
+}
+
+// Importer reads secrets out of an external password manager's export
+// format or credential file.
+type Importer interface {
+	// Label identifies the format in the Import wizard's menu.
+	Label() string
+	// Detect reports whether path looks like this format.
+	Detect(path string) bool
+	// Iterate streams the secrets found at path on the returned channel,
+	// which is closed once iteration finishes or ctx is cancelled.
+	Iterate(ctx context.Context, path string) (<-chan ImportedSecret, error)
+}
+
+var registered []Importer
+
+// Register adds an Importer to the list consulted by Detect and offered
+// by the Import wizard when the format can't be auto-detected.
+func Register(i Importer) {
+	registered = append(registered, i)
+}
+
+// All returns the registered importers, in registration order.
+func All() []Importer {
+	return append([]Importer(nil), registered...)
+}
+
+// Detect returns the first registered importer that recognizes path, or
+// nil if none do.
+func Detect(path string) Importer {
+	for _, i := range registered {
+		if i.Detect(path) {
+			return i
+		}
+	}
+	return nil
+}
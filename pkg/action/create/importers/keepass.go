@@ -0,0 +1,104 @@
+package importers
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"os"
+
+	"github.com/justwatchcom/gopass/pkg/fsutil"
+	"github.com/justwatchcom/gopass/pkg/store/secret"
+)
+
+func init() {
+	Register(keepassXML{})
+}
+
+// keepassXML imports KeePass's plaintext "XML (unencrypted)" export.
+// Encrypted .kdbx databases are out of scope here - open them in
+// KeePass/KeePassXC and export to this format first.
+type keepassXML struct{}
+
+func (keepassXML) Label() string { return "KeePass XML export" }
+
+func (keepassXML) Detect(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	var doc keepassDoc
+	return xml.NewDecoder(f).Decode(&doc) == nil && len(doc.Root.Group.Entries) > 0
+}
+
+type keepassDoc struct {
+	Root struct {
+		Group keepassGroup `xml:"Group"`
+	} `xml:"Root"`
+}
+
+type keepassGroup struct {
+	Entries []keepassEntry `xml:"Entry"`
+	Groups  []keepassGroup `xml:"Group"`
+}
+
+type keepassEntry struct {
+	Strings []struct {
+		Key   string `xml:"Key"`
+		Value string `xml:"Value"`
+	} `xml:"String"`
+}
+
+func (e keepassEntry) value(key string) string {
+	for _, s := range e.Strings {
+		if s.Key == key {
+			return s.Value
+		}
+	}
+	return ""
+}
+
+func (keepassXML) Iterate(ctx context.Context, path string) (<-chan ImportedSecret, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc keepassDoc
+	err = xml.NewDecoder(f).Decode(&doc)
+	f.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan ImportedSecret)
+	go func() {
+		defer close(out)
+
+		var walk func(g keepassGroup) bool
+		walk = func(g keepassGroup) bool {
+			for _, e := range g.Entries {
+				title := e.value("Title")
+				username := e.value("UserName")
+				sec := secret.New(e.value("Password"), "")
+				_ = sec.SetValue("username", username)
+				_ = sec.SetValue("url", e.value("URL"))
+				is := ImportedSecret{
+					Name:   fmt.Sprintf("keepass/%s/%s", fsutil.CleanFilename(title), fsutil.CleanFilename(username)),
+					Secret: sec,
+				}
+				if !send(ctx, out, is) {
+					return false
+				}
+			}
+			for _, sg := range g.Groups {
+				if !walk(sg) {
+					return false
+				}
+			}
+			return true
+		}
+		walk(doc.Root.Group)
+	}()
+	return out, nil
+}
@@ -0,0 +1,230 @@
+package create
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/justwatchcom/gopass/pkg/clipboard"
+	"github.com/justwatchcom/gopass/pkg/fsutil"
+	"github.com/justwatchcom/gopass/pkg/out"
+	"github.com/justwatchcom/gopass/pkg/store"
+	"github.com/justwatchcom/gopass/pkg/store/secret"
+	"github.com/mdp/qrterminal"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/hotp"
+	"github.com/pquerna/otp/totp"
+	"github.com/urfave/cli"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+func init() {
+	RegisterType("One-Time Password (TOTP)", &totpType{})
+	RegisterType("One-Time Password (HOTP)", &hotpType{})
+}
+
+// otpFields returns the prompts shared by the TOTP and HOTP wizards, with
+// either a period (TOTP) or an initial counter (HOTP) appended.
+func otpFields(hasCounter bool) []Field {
+	fields := []Field{
+		{Name: "issuer", Prompt: "Please enter the issuer (e.g. GitHub)", Required: true},
+		{Name: "account", Prompt: "Please enter the account name (e.g. you@example.com)", Required: true},
+		{Name: "secret", Prompt: "Please enter the secret (base32) or a full otpauth:// URI", Secret: true, Required: true},
+		{Name: "algorithm", Prompt: "Please enter the algorithm (SHA1/SHA256/SHA512)", Default: "SHA1"},
+		{Name: "digits", Prompt: "Please enter the number of digits (6/8)", Default: "6"},
+	}
+	if hasCounter {
+		fields = append(fields, Field{Name: "counter", Prompt: "Please enter the initial counter value", Default: "0"})
+	} else {
+		fields = append(fields, Field{Name: "period", Prompt: "Please enter the period in seconds", Default: "30"})
+	}
+	return fields
+}
+
+// buildOTPKey turns the answered Fields into an *otp.Key, accepting
+// either a bare base32 secret or a full otpauth:// URI in the "secret"
+// field.
+func buildOTPKey(v Values, kind string) (*otp.Key, error) {
+	if strings.HasPrefix(v["secret"], "otpauth://") {
+		return otp.NewKeyFromURL(v["secret"])
+	}
+
+	q := url.Values{}
+	q.Set("secret", v["secret"])
+	q.Set("issuer", v["issuer"])
+	q.Set("algorithm", strings.ToUpper(orDefault(v["algorithm"], "SHA1")))
+	q.Set("digits", orDefault(v["digits"], "6"))
+	if kind == "hotp" {
+		q.Set("counter", orDefault(v["counter"], "0"))
+	} else {
+		q.Set("period", orDefault(v["period"], "30"))
+	}
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     kind,
+		Path:     "/" + url.PathEscape(fmt.Sprintf("%s:%s", v["issuer"], v["account"])),
+		RawQuery: q.Encode(),
+	}
+	return otp.NewKeyFromURL(u.String())
+}
+
+func orDefault(v, def string) string {
+	if v == "" {
+		return def
+	}
+	return v
+}
+
+// showOTPQRCode renders the key's provisioning URI as a terminal QR code
+// so a phone authenticator can enroll immediately. It's shown by default
+// only when stdout is a terminal, so scripted/piped invocations don't get
+// QR block characters dumped into redirected output; --qr explicitly
+// forces it on or off either way.
+func showOTPQRCode(ctx context.Context, c *cli.Context, key *otp.Key) {
+	if c.IsSet("qr") {
+		if !c.Bool("qr") {
+			return
+		}
+	} else if !terminal.IsTerminal(int(os.Stdout.Fd())) {
+		return
+	}
+	out.Print(ctx, "Scan this QR code with your authenticator app:")
+	qrterminal.GenerateHalfBlock(key.String(), qrterminal.L, out.Stdout)
+}
+
+// totpType implements TypeFactory for TOTP secrets.
+type totpType struct{ baseType }
+
+func (totpType) Announce(ctx context.Context) { out.Green(ctx, "Creating TOTP secret ...") }
+
+func (totpType) Fields(Values) []Field { return otpFields(false) }
+
+func (totpType) PasswordField() string { return "" }
+
+func (totpType) Path(v Values) string {
+	return fmt.Sprintf("totp/%s/%s", fsutil.CleanFilename(v["issuer"]), fsutil.CleanFilename(v["account"]))
+}
+
+func (totpType) Build(ctx context.Context, v Values) (store.Secret, error) {
+	key, err := buildOTPKey(v, "totp")
+	if err != nil {
+		return nil, fmt.Errorf("invalid TOTP secret: %s", err)
+	}
+	sec := secret.New(key.Secret(), "")
+	_ = sec.SetValue("issuer", key.Issuer())
+	_ = sec.SetValue("account", key.AccountName())
+	_ = sec.SetValue("algorithm", key.Algorithm().String())
+	_ = sec.SetValue("digits", key.Digits().String())
+	_ = sec.SetValue("period", orDefault(v["period"], "30"))
+	_ = sec.SetValue("otpauth", key.String())
+	return sec, nil
+}
+
+func (totpType) AfterSave(ctx context.Context, c *cli.Context, name string, sec store.Secret, v Values) error {
+	key, err := buildOTPKey(v, "totp")
+	if err != nil {
+		return err
+	}
+	showOTPQRCode(ctx, c, key)
+	if c.Bool("print") {
+		return nil
+	}
+	period := otpPeriod(v)
+	return copyOTPCode(ctx, name, key.Secret(), period, func(secret string, at time.Time) (string, error) {
+		return totp.GenerateCodeCustom(secret, at, totp.ValidateOpts{
+			Period:    uint(period),
+			Digits:    key.Digits(),
+			Algorithm: key.Algorithm(),
+		})
+	})
+}
+
+// otpPeriod parses the TOTP "period" field, falling back to the standard
+// 30 seconds if it's missing or invalid.
+func otpPeriod(v Values) int {
+	period, err := strconv.Atoi(orDefault(v["period"], "30"))
+	if err != nil || period <= 0 {
+		return 30
+	}
+	return period
+}
+
+// hotpType implements TypeFactory for HOTP (counter-based) secrets.
+type hotpType struct{ baseType }
+
+func (hotpType) Announce(ctx context.Context) { out.Green(ctx, "Creating HOTP secret ...") }
+
+func (hotpType) Fields(Values) []Field { return otpFields(true) }
+
+func (hotpType) PasswordField() string { return "" }
+
+func (hotpType) Path(v Values) string {
+	return fmt.Sprintf("hotp/%s/%s", fsutil.CleanFilename(v["issuer"]), fsutil.CleanFilename(v["account"]))
+}
+
+func (hotpType) Build(ctx context.Context, v Values) (store.Secret, error) {
+	key, err := buildOTPKey(v, "hotp")
+	if err != nil {
+		return nil, fmt.Errorf("invalid HOTP secret: %s", err)
+	}
+	sec := secret.New(key.Secret(), "")
+	_ = sec.SetValue("issuer", key.Issuer())
+	_ = sec.SetValue("account", key.AccountName())
+	_ = sec.SetValue("algorithm", key.Algorithm().String())
+	_ = sec.SetValue("digits", key.Digits().String())
+	_ = sec.SetValue("counter", orDefault(v["counter"], "0"))
+	_ = sec.SetValue("otpauth", key.String())
+	return sec, nil
+}
+
+func (hotpType) AfterSave(ctx context.Context, c *cli.Context, name string, sec store.Secret, v Values) error {
+	key, err := buildOTPKey(v, "hotp")
+	if err != nil {
+		return err
+	}
+	showOTPQRCode(ctx, c, key)
+	if c.Bool("print") {
+		return nil
+	}
+	counter, err := strconv.ParseUint(orDefault(v["counter"], "0"), 10, 64)
+	if err != nil {
+		return err
+	}
+	return copyOTPCode(ctx, name, key.Secret(), 0, func(secret string, _ time.Time) (string, error) {
+		return hotp.GenerateCodeCustom(secret, counter, hotp.ValidateOpts{
+			Digits:    key.Digits(),
+			Algorithm: key.Algorithm(),
+		})
+	})
+}
+
+// hotpClipTimeout is the clipboard-clear timeout used for HOTP codes,
+// which - unlike TOTP - don't rotate on their own and so have no natural
+// expiry to time the clear to.
+const hotpClipTimeout = defaultClipTimeout
+
+// copyOTPCode copies the current OTP code to the clipboard instead of the
+// raw seed. For TOTP, period is the rotation period in seconds and the
+// clipboard clear (and the printed countdown) is timed to when the code
+// next rotates; pass period 0 for HOTP, whose codes don't expire on
+// their own, to skip the countdown and fall back to hotpClipTimeout.
+func copyOTPCode(ctx context.Context, name, secret string, period int, generate func(string, time.Time) (string, error)) error {
+	now := time.Now()
+	code, err := generate(secret, now)
+	if err != nil {
+		return fmt.Errorf("failed to generate code: %s", err)
+	}
+
+	if period <= 0 {
+		out.Yellow(ctx, "Current code: %s", code)
+		return clipboard.CopyTo(ctx, name, []byte(code), hotpClipTimeout)
+	}
+
+	remaining := time.Duration(int64(period)-now.Unix()%int64(period)) * time.Second
+	out.Yellow(ctx, "Current code: %s (expires in %s)", code, remaining)
+	return clipboard.CopyTo(ctx, name, []byte(code), remaining)
+}
@@ -0,0 +1,56 @@
+package create
+
+import "testing"
+
+func TestBuildOTPKey(t *testing.T) {
+	v := Values{
+		"issuer":  "GitHub",
+		"account": "me@example.com",
+		"secret":  "JBSWY3DPEHPK3PXP",
+	}
+
+	key, err := buildOTPKey(v, "totp")
+	if err != nil {
+		t.Fatalf("buildOTPKey: %s", err)
+	}
+	if key.Issuer() != "GitHub" {
+		t.Errorf("Issuer() = %q, want %q", key.Issuer(), "GitHub")
+	}
+	if key.AccountName() != "me@example.com" {
+		t.Errorf("AccountName() = %q, want %q", key.AccountName(), "me@example.com")
+	}
+	if key.Secret() != "JBSWY3DPEHPK3PXP" {
+		t.Errorf("Secret() = %q, want %q", key.Secret(), "JBSWY3DPEHPK3PXP")
+	}
+}
+
+func TestBuildOTPKeyFromURI(t *testing.T) {
+	v := Values{"secret": "otpauth://totp/GitHub:me@example.com?secret=JBSWY3DPEHPK3PXP&issuer=GitHub"}
+
+	key, err := buildOTPKey(v, "totp")
+	if err != nil {
+		t.Fatalf("buildOTPKey: %s", err)
+	}
+	if key.Issuer() != "GitHub" {
+		t.Errorf("Issuer() = %q, want %q", key.Issuer(), "GitHub")
+	}
+}
+
+func TestOTPPeriod(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		v    Values
+		want int
+	}{
+		{"default", Values{}, 30},
+		{"explicit", Values{"period": "60"}, 60},
+		{"invalid falls back", Values{"period": "not a number"}, 30},
+		{"zero falls back", Values{"period": "0"}, 30},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := otpPeriod(tc.v); got != tc.want {
+				t.Errorf("otpPeriod(%+v) = %d, want %d", tc.v, got, tc.want)
+			}
+		})
+	}
+}
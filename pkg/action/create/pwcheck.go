@@ -0,0 +1,81 @@
+package create
+
+import (
+	"context"
+
+	"github.com/justwatchcom/gopass/pkg/action"
+	"github.com/justwatchcom/gopass/pkg/out"
+	"github.com/justwatchcom/gopass/pkg/pwgen/strength"
+	"github.com/justwatchcom/gopass/pkg/termio"
+	"github.com/urfave/cli"
+)
+
+// defaultMinAcceptableGrade is used when neither the min-grade config
+// value nor the --min-grade flag is set.
+const defaultMinAcceptableGrade = 2
+
+// checkPasswordStrength estimates the strength of a manually entered
+// password and prints an entropy/crack-time estimate, optionally checking
+// it against known breaches (--check-breached or the check_breached
+// config value), and requires confirmation before accepting anything
+// below the configured minimum grade (--min-grade or the min_grade
+// config value). Entirely skipped by --no-check, for scripted use.
+func checkPasswordStrength(ctx context.Context, c *cli.Context, password string) error {
+	if password == "" {
+		return nil
+	}
+
+	score := strength.Estimate(password)
+	out.Print(ctx, "Password strength: %s (estimated crack time: %s)", strengthLabel(score.Grade), score.CrackTime)
+
+	if checkBreachedEnabled(c) {
+		n, err := strength.CheckBreached(ctx, password)
+		if err != nil {
+			out.Error(ctx, "failed to check for known breaches: %s", err)
+		} else if n > 0 {
+			out.Red(ctx, "This password has been seen in %d known data breaches!", n)
+		}
+	}
+
+	if score.Grade >= minAcceptableGrade(c) {
+		return nil
+	}
+
+	ok, err := termio.AskForBool(ctx, "This password is weak, use it anyway?", false)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return action.ExitError(ctx, action.ExitAborted, nil, "user aborted on weak password")
+	}
+	return nil
+}
+
+func checkBreachedEnabled(c *cli.Context) bool {
+	if c.IsSet("check-breached") {
+		return c.Bool("check-breached")
+	}
+	return c.GlobalBool("check-breached")
+}
+
+// minAcceptableGrade resolves the lowest strength.Score.Grade accepted
+// without an explicit confirmation from the user, in order of preference:
+// the --min-grade flag, the min_grade config value, then
+// defaultMinAcceptableGrade.
+func minAcceptableGrade(c *cli.Context) int {
+	if c.IsSet("min-grade") {
+		return c.Int("min-grade")
+	}
+	if c.GlobalIsSet("min-grade") {
+		return c.GlobalInt("min-grade")
+	}
+	return defaultMinAcceptableGrade
+}
+
+func strengthLabel(grade int) string {
+	labels := []string{"very weak", "weak", "fair", "strong", "very strong"}
+	if grade < 0 || grade >= len(labels) {
+		return "unknown"
+	}
+	return labels[grade]
+}
@@ -0,0 +1,93 @@
+package create
+
+import (
+	"context"
+
+	"github.com/justwatchcom/gopass/pkg/store"
+	"github.com/urfave/cli"
+)
+
+// Field describes a single prompt in a secret-type wizard.
+type Field struct {
+	// Name identifies the field and is used as the key under which the
+	// answer is collected in Values.
+	Name string
+	// Prompt is shown to the user when asking for this field.
+	Prompt string
+	// Default is pre-filled and returned when the user accepts an empty
+	// answer.
+	Default string
+	// Required aborts the wizard if the user provides an empty answer.
+	Required bool
+	// Secret marks the field as sensitive; it is read with
+	// termio.AskForPassword instead of termio.AskForString.
+	Secret bool
+	// Generatable additionally offers to generate the value instead of
+	// asking for it directly, via the owning TypeFactory's
+	// GeneratePassword method.
+	Generatable bool
+	// Validate, if set, is run against the raw answer before it is
+	// accepted. A returned error is shown to the user and aborts the
+	// wizard.
+	Validate func(string) error
+}
+
+// Values holds the answers collected for a type's Fields, keyed by
+// Field.Name.
+type Values map[string]string
+
+// TypeFactory describes a pluggable secret type for the create wizard.
+// Types are registered with RegisterType and driven generically by
+// creator.runType, which asks for each Field, builds and saves the
+// secret and hands off to createPrintOrCopy.
+type TypeFactory interface {
+	// Announce prints a short banner describing what's being created,
+	// shown once the user picks this type.
+	Announce(ctx context.Context)
+	// Fields returns the prompts that make up this type's wizard. It is
+	// called again before every field is asked, with the answers
+	// collected so far, so that later fields can use Default values
+	// derived from earlier answers (e.g. GCP pre-filling the service
+	// account name from the uploaded JSON) without a TypeFactory having
+	// to keep that state itself - factories are registered once and
+	// reused for the life of the process, so any state kept on the
+	// factory itself would leak between invocations of the wizard.
+	Fields(v Values) []Field
+	// PasswordField names the Field whose value should be offered for
+	// printing/copying via createPrintOrCopy, or "" if this type has
+	// nothing to print/copy (e.g. AWS and GCP credentials).
+	PasswordField() string
+	// GeneratePassword is called when the user opts to generate the
+	// PasswordField's value instead of entering it, prompting for any
+	// parameters it needs (e.g. passphrase length).
+	GeneratePassword(ctx context.Context) (string, error)
+	// Path builds the secret's store path from the answered Fields.
+	Path(v Values) string
+	// Build constructs the secret to persist from the collected
+	// answers, prompting for any additional type-specific data first
+	// (e.g. Generic's free-form key/value pairs).
+	Build(ctx context.Context, v Values) (store.Secret, error)
+	// AfterSave runs once the secret has been persisted, for
+	// type-specific side effects that don't fit PasswordField's generic
+	// print-or-copy handling (e.g. TOTP/HOTP printing a QR code and
+	// copying the current code instead of the raw seed). Types that
+	// don't need this can embed baseType, which no-ops.
+	AfterSave(ctx context.Context, c *cli.Context, name string, sec store.Secret, v Values) error
+}
+
+var (
+	registry      = map[string]TypeFactory{}
+	registryOrder []string
+)
+
+// RegisterType adds a new secret type to the create wizard's type
+// selection menu under the given label. Downstream binaries can call this
+// from an init() function to extend gopass with additional secret types
+// (e.g. SSH keys, TLS keypairs, database URIs, Kubernetes kubeconfigs)
+// without editing this package.
+func RegisterType(label string, factory TypeFactory) {
+	if _, ok := registry[label]; !ok {
+		registryOrder = append(registryOrder, label)
+	}
+	registry[label] = factory
+}
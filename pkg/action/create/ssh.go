@@ -0,0 +1,118 @@
+package create
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/justwatchcom/gopass/pkg/fsutil"
+	"github.com/justwatchcom/gopass/pkg/out"
+	"github.com/justwatchcom/gopass/pkg/store"
+	"github.com/justwatchcom/gopass/pkg/store/secret"
+	"golang.org/x/crypto/ssh"
+)
+
+func init() {
+	RegisterType("SSH Keypair", &sshType{})
+}
+
+// sshType implements TypeFactory for SSH keypairs, either imported from an
+// existing private/public key pair or freshly generated via ssh-keygen.
+type sshType struct{ baseType }
+
+func (sshType) Announce(ctx context.Context) { out.Green(ctx, "Creating SSH keypair ...") }
+
+func (sshType) Fields(Values) []Field {
+	return []Field{
+		{Name: "name", Prompt: "Please enter a name for this key (e.g. deploy@example.com)", Required: true},
+		{Name: "privatekeyfile", Prompt: "Path to an existing private key to import (leave empty to generate a new one)"},
+	}
+}
+
+func (sshType) PasswordField() string { return "" }
+
+func (sshType) Path(v Values) string {
+	return fmt.Sprintf("ssh/%s", fsutil.CleanFilename(v["name"]))
+}
+
+func (sshType) Build(ctx context.Context, v Values) (store.Secret, error) {
+	var (
+		priv, pub []byte
+		err       error
+	)
+	if fn := v["privatekeyfile"]; fn != "" {
+		priv, pub, err = importSSHKeypair(fn)
+	} else {
+		priv, pub, err = generateSSHKeypair(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	fp, err := sshFingerprint(pub)
+	if err != nil {
+		return nil, err
+	}
+
+	sec := secret.New(string(priv), "")
+	_ = sec.SetValue("public-key", string(pub))
+	_ = sec.SetValue("fingerprint", fp)
+	return sec, nil
+}
+
+// importSSHKeypair reads an existing private key and derives its public
+// half, preferring a "<path>.pub" sibling and falling back to deriving it
+// from the private key itself.
+func importSSHKeypair(fn string) ([]byte, []byte, error) {
+	priv, err := ioutil.ReadFile(fn)
+	if err != nil {
+		return nil, nil, err
+	}
+	if pub, err := ioutil.ReadFile(fn + ".pub"); err == nil {
+		return priv, pub, nil
+	}
+	signer, err := ssh.ParsePrivateKey(priv)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to derive public key: %s", err)
+	}
+	return priv, ssh.MarshalAuthorizedKey(signer.PublicKey()), nil
+}
+
+// generateSSHKeypair shells out to ssh-keygen to create a fresh ed25519
+// keypair in a temporary directory and reads the result back in.
+func generateSSHKeypair(ctx context.Context) ([]byte, []byte, error) {
+	dir, err := ioutil.TempDir("", "gopass-sshkeygen")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "id_ed25519")
+	cmd := exec.CommandContext(ctx, "ssh-keygen", "-t", "ed25519", "-N", "", "-f", path, "-C", "gopass")
+	if outb, err := cmd.CombinedOutput(); err != nil {
+		return nil, nil, fmt.Errorf("ssh-keygen failed: %s: %s", err, outb)
+	}
+
+	priv, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	pub, err := ioutil.ReadFile(path + ".pub")
+	if err != nil {
+		return nil, nil, err
+	}
+	return priv, pub, nil
+}
+
+// sshFingerprint returns the SHA256 fingerprint of an authorized_keys
+// formatted public key, in the same format as `ssh-keygen -lf`.
+func sshFingerprint(pub []byte) (string, error) {
+	pk, _, _, _, err := ssh.ParseAuthorizedKey(pub)
+	if err != nil {
+		return "", err
+	}
+	return ssh.FingerprintSHA256(pk), nil
+}
@@ -0,0 +1,35 @@
+package create
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func TestSSHFingerprint(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %s", err)
+	}
+	sshPub, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatalf("NewPublicKey: %s", err)
+	}
+
+	want := ssh.FingerprintSHA256(sshPub)
+	got, err := sshFingerprint(ssh.MarshalAuthorizedKey(sshPub))
+	if err != nil {
+		t.Fatalf("sshFingerprint: %s", err)
+	}
+	if got != want {
+		t.Errorf("sshFingerprint() = %q, want %q", got, want)
+	}
+}
+
+func TestSSHFingerprintInvalid(t *testing.T) {
+	if _, err := sshFingerprint([]byte("not a key")); err == nil {
+		t.Error("expected an error for an invalid public key, got nil")
+	}
+}
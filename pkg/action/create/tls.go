@@ -0,0 +1,67 @@
+package create
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/justwatchcom/gopass/pkg/fsutil"
+	"github.com/justwatchcom/gopass/pkg/out"
+	"github.com/justwatchcom/gopass/pkg/store"
+	"github.com/justwatchcom/gopass/pkg/store/secret"
+)
+
+func init() {
+	RegisterType("TLS Keypair", &tlsType{})
+}
+
+// tlsType implements TypeFactory for TLS certificate/key pairs. It
+// validates that the two halves match and extracts the certificate's
+// subject, SANs and expiry into structured fields.
+type tlsType struct{ baseType }
+
+func (tlsType) Announce(ctx context.Context) { out.Green(ctx, "Creating TLS keypair ...") }
+
+func (tlsType) Fields(Values) []Field {
+	return []Field{
+		{Name: "name", Prompt: "Please enter a name for this certificate", Required: true},
+		{Name: "certfile", Prompt: "Path to the PEM certificate file", Required: true},
+		{Name: "keyfile", Prompt: "Path to the PEM private key file", Required: true},
+	}
+}
+
+func (tlsType) PasswordField() string { return "" }
+
+func (tlsType) Path(v Values) string {
+	return fmt.Sprintf("tls/%s", fsutil.CleanFilename(v["name"]))
+}
+
+func (tlsType) Build(ctx context.Context, v Values) (store.Secret, error) {
+	certPEM, err := ioutil.ReadFile(v["certfile"])
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := ioutil.ReadFile(v["keyfile"])
+	if err != nil {
+		return nil, err
+	}
+
+	pair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("certificate and key do not match: %s", err)
+	}
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, err
+	}
+
+	sec := secret.New(string(keyPEM), string(certPEM))
+	_ = sec.SetValue("common-name", cert.Subject.CommonName)
+	_ = sec.SetValue("dns-names", strings.Join(cert.DNSNames, ","))
+	_ = sec.SetValue("not-after", cert.NotAfter.Format(time.RFC3339))
+	return sec, nil
+}
@@ -0,0 +1,225 @@
+package create
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/justwatchcom/gopass/pkg/fsutil"
+	"github.com/justwatchcom/gopass/pkg/out"
+	"github.com/justwatchcom/gopass/pkg/pwgen"
+	"github.com/justwatchcom/gopass/pkg/store"
+	"github.com/justwatchcom/gopass/pkg/store/secret"
+	"github.com/justwatchcom/gopass/pkg/termio"
+)
+
+// websiteType implements TypeFactory for website logins.
+type websiteType struct{ baseType }
+
+func (websiteType) Announce(ctx context.Context) { out.Green(ctx, "Creating Website login ...") }
+
+func (websiteType) Fields(Values) []Field {
+	return []Field{
+		{Name: "url", Prompt: "Please enter the URL", Required: true, Validate: func(v string) error {
+			if extractHostname(v) == "" {
+				return fmt.Errorf("can not parse URL '%s', please use 'gopass edit' to manually create the secret", v)
+			}
+			return nil
+		}},
+		{Name: "username", Prompt: "Please enter the Username/Login"},
+		{Name: "password", Prompt: "Please enter the password", Secret: true, Generatable: true},
+		{Name: "comment", Prompt: "Comments (optional)"},
+	}
+}
+
+func (websiteType) PasswordField() string { return "password" }
+
+func (websiteType) GeneratePassword(ctx context.Context) (string, error) {
+	return createGeneratePassword(ctx)
+}
+
+func (websiteType) Path(v Values) string {
+	return fmt.Sprintf("websites/%s/%s", fsutil.CleanFilename(extractHostname(v["url"])), fsutil.CleanFilename(v["username"]))
+}
+
+func (websiteType) Build(ctx context.Context, v Values) (store.Secret, error) {
+	sec := secret.New(v["password"], "")
+	_ = sec.SetValue("url", v["url"])
+	_ = sec.SetValue("username", v["username"])
+	_ = sec.SetValue("comment", v["comment"])
+	return sec, nil
+}
+
+// pinType implements TypeFactory for numerical PINs.
+type pinType struct{ baseType }
+
+func (pinType) Announce(ctx context.Context) { out.Green(ctx, "Creating numerical PIN ...") }
+
+func (pinType) Fields(Values) []Field {
+	return []Field{
+		{Name: "authority", Prompt: "Please enter the authoriy (e.g. MyBank) this PIN is for", Required: true},
+		{Name: "application", Prompt: "Please enter the entity (e.g. Credit Card) this PIN is for", Required: true},
+		{Name: "password", Prompt: "PIN", Secret: true, Generatable: true},
+		{Name: "comment", Prompt: "Comments (optional)"},
+	}
+}
+
+func (pinType) PasswordField() string { return "password" }
+
+func (pinType) GeneratePassword(ctx context.Context) (string, error) {
+	length, err := termio.AskForInt(ctx, "How long should the PIN be?", 4)
+	if err != nil {
+		return "", err
+	}
+	return pwgen.GeneratePasswordCharset(length, "0123456789"), nil
+}
+
+func (pinType) Path(v Values) string {
+	return fmt.Sprintf("pins/%s/%s", fsutil.CleanFilename(v["authority"]), fsutil.CleanFilename(v["application"]))
+}
+
+func (pinType) Build(ctx context.Context, v Values) (store.Secret, error) {
+	sec := secret.New(v["password"], "")
+	_ = sec.SetValue("application", v["application"])
+	_ = sec.SetValue("comment", v["comment"])
+	return sec, nil
+}
+
+// genericType implements TypeFactory for generic secrets with free-form
+// key/value pairs collected after the static fields.
+type genericType struct{ baseType }
+
+func (genericType) Announce(ctx context.Context) { out.Green(ctx, "Creating generic secret ...") }
+
+func (genericType) Fields(Values) []Field {
+	return []Field{
+		{Name: "shortname", Prompt: "Please enter a name for the secret", Required: true},
+		{Name: "password", Prompt: "password", Secret: true, Generatable: true},
+	}
+}
+
+func (genericType) PasswordField() string { return "password" }
+
+func (genericType) GeneratePassword(ctx context.Context) (string, error) {
+	return createGeneratePassword(ctx)
+}
+
+func (genericType) Path(v Values) string {
+	return fmt.Sprintf("misc/%s", fsutil.CleanFilename(v["shortname"]))
+}
+
+func (genericType) Build(ctx context.Context, v Values) (store.Secret, error) {
+	sec := secret.New(v["password"], "")
+	out.Print(ctx, "Enter zero or more key value pairs for this secret:")
+	for {
+		key, err := termio.AskForString(ctx, "Name for Key Value pair (enter to quit)", "")
+		if err != nil {
+			return nil, err
+		}
+		if key == "" {
+			break
+		}
+		val, err := termio.AskForString(ctx, "Value for Key '"+key+"'", "")
+		if err != nil {
+			return nil, err
+		}
+		_ = sec.SetValue(key, val)
+	}
+	return sec, nil
+}
+
+// awsType implements TypeFactory for AWS IAM credentials.
+type awsType struct{ baseType }
+
+func (awsType) Announce(ctx context.Context) { out.Green(ctx, "Creating AWS credentials ...") }
+
+func (awsType) Fields(Values) []Field {
+	return []Field{
+		{Name: "account", Prompt: "Please enter the AWS Account this key belongs to", Required: true},
+		{Name: "username", Prompt: "Please enter the name of the AWS IAM User this key belongs to", Required: true},
+		{Name: "accesskey", Prompt: "Please enter the Access Key ID (AWS_ACCESS_KEY_ID)"},
+		{Name: "secretkey", Prompt: "Please enter the Secret Access Key (AWS_SECRET_ACCESS_KEY)", Secret: true},
+		{Name: "region", Prompt: "Please enter the default Region (AWS_DEFAULT_REGION) (optional)"},
+	}
+}
+
+func (awsType) PasswordField() string { return "" }
+
+func (awsType) Path(v Values) string {
+	return fmt.Sprintf("aws/iam/%s/%s", fsutil.CleanFilename(v["account"]), fsutil.CleanFilename(v["username"]))
+}
+
+func (awsType) Build(ctx context.Context, v Values) (store.Secret, error) {
+	sec := secret.New(v["secretkey"], "")
+	_ = sec.SetValue("account", v["account"])
+	_ = sec.SetValue("username", v["username"])
+	_ = sec.SetValue("accesskey", v["accesskey"])
+	_ = sec.SetValue("region", v["region"])
+	return sec, nil
+}
+
+// gcpType implements TypeFactory for GCP service accounts, imported from a
+// service account JSON file.
+type gcpType struct{ baseType }
+
+func (gcpType) Announce(ctx context.Context) { out.Green(ctx, "Creating GCP credentials ...") }
+
+// Fields re-reads the service account file named by a previous answer (if
+// any) on every call rather than caching its contents on gcpType, so the
+// factory - registered once and reused for the life of the process - stays
+// a stateless schema like every other TypeFactory.
+func (gcpType) Fields(v Values) []Field {
+	username, project, _ := extractGCPInfo(readGCPFile(v["svcaccfn"]))
+	return []Field{
+		{Name: "svcaccfn", Prompt: "Please enter path to the Service Account JSON file", Required: true, Validate: func(v string) error {
+			_, err := ioutil.ReadFile(v)
+			return err
+		}},
+		{Name: "username", Prompt: "Please enter the name of this service account", Default: username, Required: true},
+		{Name: "project", Prompt: "Please enter the name of this GCP project", Default: project, Required: true},
+	}
+}
+
+func (gcpType) PasswordField() string { return "" }
+
+func (gcpType) Path(v Values) string {
+	return fmt.Sprintf("gcp/iam/%s/%s", fsutil.CleanFilename(v["project"]), fsutil.CleanFilename(v["username"]))
+}
+
+func (gcpType) Build(ctx context.Context, v Values) (store.Secret, error) {
+	buf, err := ioutil.ReadFile(v["svcaccfn"])
+	if err != nil {
+		return nil, err
+	}
+	return secret.New("", string(buf)), nil
+}
+
+// readGCPFile reads path, returning nil if it's empty or unreadable -
+// callers only use the result to derive optional Field defaults.
+func readGCPFile(path string) []byte {
+	if path == "" {
+		return nil
+	}
+	buf, _ := ioutil.ReadFile(path)
+	return buf
+}
+
+// extractGCPInfo will extract the GCP details from the given json blob
+func extractGCPInfo(buf []byte) (string, string, error) {
+	var m map[string]string
+	if err := json.Unmarshal(buf, &m); err != nil {
+		return "", "", err
+	}
+	p := strings.Split(m["client_email"], "@")
+	if len(p) < 2 {
+		return "", "", fmt.Errorf("client_email contains no email")
+	}
+	username := p[0]
+	p = strings.Split(p[1], ".")
+	if len(p) < 1 {
+		return username, "", fmt.Errorf("hostname contains not enough separators")
+	}
+	return username, p[0], nil
+}
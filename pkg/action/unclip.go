@@ -0,0 +1,22 @@
+package action
+
+import (
+	"context"
+	"time"
+
+	"github.com/justwatchcom/gopass/pkg/clipboard"
+	"github.com/urfave/cli"
+)
+
+// Unclip is the hidden "gopass unclip" command that pkg/clipboard.CopyTo
+// re-execs itself as, in order to clear the clipboard after a timeout
+// without keeping the original command's process alive. It is not meant
+// to be invoked directly.
+func Unclip(ctx context.Context, c *cli.Context) error {
+	timeout := time.Duration(c.Int("timeout")) * time.Second
+
+	if err := clipboard.Unclip(ctx, timeout); err != nil {
+		return ExitError(ctx, ExitUnknown, err, "failed to clear clipboard: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// Package clipboard wraps the system clipboard and adds a safety net for
+// copied secrets: contents can be cleared automatically after a timeout,
+// restoring whatever was in the clipboard before the copy.
+package clipboard
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/atotto/clipboard"
+)
+
+const (
+	// envChecksum carries the sha256 of the content CopyTo wrote to the
+	// clipboard, so the detached unclip helper can tell whether it's
+	// still safe to clear (i.e. nothing else has overwritten the
+	// clipboard in the meantime).
+	envChecksum = "GOPASS_UNCLIP_CHECKSUM"
+	// envPrevious carries the base64-encoded clipboard contents from
+	// before the copy, for the unclip helper to restore.
+	envPrevious = "GOPASS_UNCLIP_PREVIOUS"
+)
+
+// CopyTo copies the given data to the clipboard. If timeout is greater
+// than zero the previous clipboard contents are saved before overwriting
+// them, and a detached "gopass unclip" helper process is spawned to clear
+// the clipboard (and restore the previous contents) once timeout elapses
+// - but only if nothing else has claimed the clipboard in the meantime.
+//
+// The clearing has to happen in a separate, detached process rather than
+// a goroutine: urfave/cli commands - like most CLI commands - exit as
+// soon as their handler returns, which would kill any in-process timer
+// before it ever fired.
+func CopyTo(ctx context.Context, name string, content []byte, timeout time.Duration) error {
+	previous, _ := clipboard.ReadAll()
+
+	if err := clipboard.WriteAll(string(content)); err != nil {
+		return fmt.Errorf("failed to write to clipboard: %s", err)
+	}
+
+	if timeout <= 0 {
+		return nil
+	}
+
+	return spawnUnclip(content, previous, timeout)
+}
+
+// spawnUnclip re-execs the running binary as a detached "unclip" helper
+// that will sleep for timeout and then clear the clipboard.
+func spawnUnclip(content []byte, previous string, timeout time.Duration) error {
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate gopass binary to clear clipboard: %s", err)
+	}
+	sum := sha256.Sum256(content)
+
+	cmd := exec.Command(self, "unclip", fmt.Sprintf("--timeout=%d", int(timeout.Seconds())))
+	cmd.Env = append(os.Environ(),
+		envChecksum+"="+base64.StdEncoding.EncodeToString(sum[:]),
+		envPrevious+"="+base64.StdEncoding.EncodeToString([]byte(previous)),
+	)
+	detach(cmd)
+	return cmd.Start()
+}
+
+// Unclip is run by the detached "gopass unclip" helper spawned by CopyTo.
+// It sleeps for timeout and then restores the clipboard to the contents
+// carried in the environment, unless the clipboard no longer contains
+// what CopyTo put there.
+func Unclip(ctx context.Context, timeout time.Duration) error {
+	ignoreHangup()
+
+	wantSum, err := base64.StdEncoding.DecodeString(os.Getenv(envChecksum))
+	if err != nil {
+		return fmt.Errorf("missing or invalid %s", envChecksum)
+	}
+	previous, err := base64.StdEncoding.DecodeString(os.Getenv(envPrevious))
+	if err != nil {
+		return fmt.Errorf("missing or invalid %s", envPrevious)
+	}
+
+	time.Sleep(timeout)
+
+	cur, err := clipboard.ReadAll()
+	if err != nil {
+		// nothing we can do without being able to read the clipboard
+		return nil
+	}
+	sum := sha256.Sum256([]byte(cur))
+	if !bytes.Equal(sum[:], wantSum) {
+		// clipboard was already changed by someone else, leave it alone
+		return nil
+	}
+
+	return clipboard.WriteAll(string(previous))
+}
@@ -0,0 +1,26 @@
+//go:build !windows
+// +build !windows
+
+package clipboard
+
+import (
+	"os/exec"
+	"os/signal"
+	"syscall"
+)
+
+// detach starts cmd in its own session so it survives the parent's
+// session ending - e.g. the user closing the terminal before the
+// clipboard-clear timeout elapses, which would otherwise send SIGHUP to
+// the whole process group and kill the helper before it ever cleared the
+// clipboard.
+func detach(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+}
+
+// ignoreHangup ignores SIGHUP as a second line of defense for the unclip
+// helper, in case it's ever invoked without having been detached into its
+// own session.
+func ignoreHangup() {
+	signal.Ignore(syscall.SIGHUP)
+}
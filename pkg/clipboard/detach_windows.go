@@ -0,0 +1,13 @@
+//go:build windows
+// +build windows
+
+package clipboard
+
+import "os/exec"
+
+// detach is a no-op on Windows: child processes aren't part of a POSIX
+// session and don't receive SIGHUP when the parent's console closes.
+func detach(cmd *exec.Cmd) {}
+
+// ignoreHangup is a no-op on Windows; see detach.
+func ignoreHangup() {}
@@ -0,0 +1,44 @@
+//go:build darwin
+// +build darwin
+
+package keyring
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+)
+
+func newPlatformKeyring() Keyring { return darwinKeyring{} }
+
+// darwinKeyring wraps the macOS Keychain via the /usr/bin/security CLI.
+type darwinKeyring struct{}
+
+// errSecItemNotFound is the exit status `security` uses for "no such
+// keychain item", as opposed to e.g. a locked keychain or a missing
+// security binary.
+const errSecItemNotFound = 44
+
+func (darwinKeyring) Set(ctx context.Context, name string, secret []byte) error {
+	// ignore the error: there may simply be nothing to delete yet
+	_ = exec.CommandContext(ctx, "security", "delete-generic-password", "-a", name, "-s", service).Run()
+	return exec.CommandContext(ctx, "security", "add-generic-password", "-a", name, "-s", service, "-w", string(secret), "-U").Run()
+}
+
+func (darwinKeyring) Get(ctx context.Context, name string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "security", "find-generic-password", "-a", name, "-s", service, "-w")
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == errSecItemNotFound {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("security find-generic-password failed: %s", err)
+	}
+	return bytes.TrimRight(out.Bytes(), "\n"), nil
+}
+
+func (darwinKeyring) Delete(ctx context.Context, name string) error {
+	return exec.CommandContext(ctx, "security", "delete-generic-password", "-a", name, "-s", service).Run()
+}
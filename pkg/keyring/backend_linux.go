@@ -0,0 +1,50 @@
+//go:build linux
+// +build linux
+
+package keyring
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+func newPlatformKeyring() Keyring { return linuxKeyring{} }
+
+// linuxKeyring wraps GNOME Keyring/KWallet via libsecret's secret-tool
+// CLI, which talks over the session D-Bus to whichever Secret Service
+// implementation is registered (GNOME Keyring, KWallet's libsecret
+// shim, ...).
+type linuxKeyring struct{}
+
+func (linuxKeyring) Set(ctx context.Context, name string, secret []byte) error {
+	cmd := exec.CommandContext(ctx, "secret-tool", "store", "--label", name, "service", service, "account", name)
+	cmd.Stdin = bytes.NewReader(secret)
+	return cmd.Run()
+}
+
+func (linuxKeyring) Get(ctx context.Context, name string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "secret-tool", "lookup", "service", service, "account", name)
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		// secret-tool exits non-zero both for "no such item" and for
+		// failures to reach the Secret Service at all (no D-Bus
+		// session, nothing implementing it, access denied); it gives
+		// us no way to tell those apart other than by guessing from
+		// stderr, so surface the message instead of always claiming
+		// not found.
+		if stderr.Len() == 0 {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("secret-tool lookup failed: %s", strings.TrimSpace(stderr.String()))
+	}
+	return out.Bytes(), nil
+}
+
+func (linuxKeyring) Delete(ctx context.Context, name string) error {
+	return exec.CommandContext(ctx, "secret-tool", "clear", "service", service, "account", name).Run()
+}
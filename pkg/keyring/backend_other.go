@@ -0,0 +1,27 @@
+//go:build !darwin && !linux && !windows
+// +build !darwin,!linux,!windows
+
+package keyring
+
+import (
+	"context"
+	"fmt"
+)
+
+func newPlatformKeyring() Keyring { return unsupportedKeyring{} }
+
+// unsupportedKeyring is used on platforms without a known native secret
+// store integration.
+type unsupportedKeyring struct{}
+
+func (unsupportedKeyring) Set(ctx context.Context, name string, secret []byte) error {
+	return fmt.Errorf("keyring: not supported on this platform")
+}
+
+func (unsupportedKeyring) Get(ctx context.Context, name string) ([]byte, error) {
+	return nil, fmt.Errorf("keyring: not supported on this platform")
+}
+
+func (unsupportedKeyring) Delete(ctx context.Context, name string) error {
+	return fmt.Errorf("keyring: not supported on this platform")
+}
@@ -0,0 +1,54 @@
+//go:build windows
+// +build windows
+
+package keyring
+
+import (
+	"context"
+	"fmt"
+	"syscall"
+
+	"github.com/danieljoos/wincred"
+)
+
+func newPlatformKeyring() Keyring { return windowsKeyring{} }
+
+// windowsKeyring wraps the Windows Credential Manager.
+type windowsKeyring struct{}
+
+// errNotFound is the Win32 error code wincred surfaces for "no such
+// credential", as opposed to e.g. Credential Manager being unavailable.
+const errNotFound = 1168 // ERROR_NOT_FOUND
+
+func (windowsKeyring) Set(ctx context.Context, name string, secret []byte) error {
+	cred := wincred.NewGenericCredential(service + ":" + name)
+	cred.CredentialBlob = secret
+	return cred.Write()
+}
+
+func (windowsKeyring) Get(ctx context.Context, name string) ([]byte, error) {
+	cred, err := wincred.GetGenericCredential(service + ":" + name)
+	if err != nil {
+		if isWincredNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("wincred GetGenericCredential failed: %s", err)
+	}
+	return cred.CredentialBlob, nil
+}
+
+func (windowsKeyring) Delete(ctx context.Context, name string) error {
+	cred, err := wincred.GetGenericCredential(service + ":" + name)
+	if err != nil {
+		if isWincredNotFound(err) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("wincred GetGenericCredential failed: %s", err)
+	}
+	return cred.Delete()
+}
+
+func isWincredNotFound(err error) bool {
+	errno, ok := err.(syscall.Errno)
+	return ok && errno == errNotFound
+}
@@ -0,0 +1,97 @@
+package keyring
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// CredentialHelper implements the "get" operation of git's
+// credential-helper protocol (see gitcredentials(7)) against the OS
+// keyring, so `git config credential.helper`, `docker login` and similar
+// tools can fetch a gopass-stored secret by name directly from the OS
+// keyring, without shelling out to gopass itself.
+//
+// It reads the "key=value" request lines documented in gitcredentials(7)
+// from r until a blank line, looks the credential up in the keyring by
+// "host" (falling back to the host embedded in "url" if "host" itself
+// wasn't sent), and writes "username=<username>\npassword=<secret>\n" to
+// w - echoing back the request's own "username" line where the caller
+// sent one, since git uses that to disambiguate between multiple stored
+// logins for the same host.
+func CredentialHelper(ctx context.Context, kr Keyring, r io.Reader, w io.Writer) error {
+	req, err := readCredentialRequest(r)
+	if err != nil {
+		return err
+	}
+
+	secret, err := kr.Get(ctx, req.host)
+	if err != nil {
+		return fmt.Errorf("no keyring entry for '%s': %s", req.host, err)
+	}
+
+	username := req.username
+	if username == "" {
+		username = req.host
+	}
+
+	_, err = fmt.Fprintf(w, "username=%s\npassword=%s\n", username, secret)
+	return err
+}
+
+// credentialRequest holds the subset of gitcredentials(7)'s "get" request
+// keys CredentialHelper cares about.
+type credentialRequest struct {
+	protocol, host, path, username, password string
+}
+
+// readCredentialRequest parses the "key=value" lines git (or a compatible
+// caller) sends on its "get" request, stopping at the first blank line.
+// If no "host" line was sent, the host is recovered from "url" instead,
+// since gitcredentials(7) allows either form.
+func readCredentialRequest(r io.Reader) (credentialRequest, error) {
+	var req credentialRequest
+	var rawurl string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			break
+		}
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		switch parts[0] {
+		case "protocol":
+			req.protocol = parts[1]
+		case "host":
+			req.host = parts[1]
+		case "path":
+			req.path = parts[1]
+		case "username":
+			req.username = parts[1]
+		case "password":
+			req.password = parts[1]
+		case "url":
+			rawurl = parts[1]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return credentialRequest{}, err
+	}
+
+	if req.host == "" && rawurl != "" {
+		if u, err := url.Parse(rawurl); err == nil {
+			req.host = u.Host
+		}
+	}
+	if req.host == "" {
+		return credentialRequest{}, fmt.Errorf("no credential host given")
+	}
+	return req, nil
+}
@@ -0,0 +1,99 @@
+package keyring
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// memKeyring is a minimal in-memory Keyring for exercising CredentialHelper
+// without touching the real OS keyring.
+type memKeyring struct{ secrets map[string][]byte }
+
+func (k *memKeyring) Set(ctx context.Context, name string, secret []byte) error {
+	k.secrets[name] = secret
+	return nil
+}
+
+func (k *memKeyring) Get(ctx context.Context, name string) ([]byte, error) {
+	secret, ok := k.secrets[name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return secret, nil
+}
+
+func (k *memKeyring) Delete(ctx context.Context, name string) error {
+	delete(k.secrets, name)
+	return nil
+}
+
+func TestReadCredentialRequest(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		in      string
+		want    credentialRequest
+		wantErr bool
+	}{
+		{
+			name: "host only, as sent by a real git credential fill",
+			in:   "protocol=https\nhost=example.com\n\n",
+			want: credentialRequest{protocol: "https", host: "example.com"},
+		},
+		{
+			name: "host and username, to disambiguate multiple logins",
+			in:   "protocol=https\nhost=example.com\nusername=alice\n\n",
+			want: credentialRequest{protocol: "https", host: "example.com", username: "alice"},
+		},
+		{
+			name: "host recovered from url when not sent directly",
+			in:   "url=https://example.com/repo.git\n\n",
+			want: credentialRequest{host: "example.com"},
+		},
+		{
+			name:    "no host at all",
+			in:      "protocol=https\n\n",
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := readCredentialRequest(strings.NewReader(tc.in))
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("readCredentialRequest() = nil error, want one")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readCredentialRequest: %s", err)
+			}
+			if got != tc.want {
+				t.Errorf("readCredentialRequest() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCredentialHelper(t *testing.T) {
+	kr := &memKeyring{secrets: map[string][]byte{"example.com": []byte("s3cr3t")}}
+
+	var out strings.Builder
+	if err := CredentialHelper(context.Background(), kr, strings.NewReader("protocol=https\nhost=example.com\nusername=alice\n\n"), &out); err != nil {
+		t.Fatalf("CredentialHelper: %s", err)
+	}
+	if want := "username=alice\npassword=s3cr3t\n"; out.String() != want {
+		t.Errorf("CredentialHelper() wrote %q, want %q", out.String(), want)
+	}
+}
+
+func TestCredentialHelperDefaultsUsernameToHost(t *testing.T) {
+	kr := &memKeyring{secrets: map[string][]byte{"example.com": []byte("s3cr3t")}}
+
+	var out strings.Builder
+	if err := CredentialHelper(context.Background(), kr, strings.NewReader("protocol=https\nhost=example.com\n\n"), &out); err != nil {
+		t.Fatalf("CredentialHelper: %s", err)
+	}
+	if want := "username=example.com\npassword=s3cr3t\n"; out.String() != want {
+		t.Errorf("CredentialHelper() wrote %q, want %q", out.String(), want)
+	}
+}
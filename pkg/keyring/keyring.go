@@ -0,0 +1,33 @@
+// Package keyring wraps the platform-native secret store - macOS
+// Keychain, GNOME libsecret/KWallet on Linux, Windows Credential
+// Manager - behind a single interface, so a secret can be handed off to
+// another process without ever touching the clipboard.
+package keyring
+
+import (
+	"context"
+	"fmt"
+)
+
+// service namespaces gopass's entries in the OS keyring.
+const service = "gopass"
+
+// Keyring stores and retrieves secrets in the platform-native secret
+// store.
+type Keyring interface {
+	// Set stores secret under name, overwriting any previous value.
+	Set(ctx context.Context, name string, secret []byte) error
+	// Get retrieves the secret stored under name.
+	Get(ctx context.Context, name string) ([]byte, error)
+	// Delete removes the secret stored under name.
+	Delete(ctx context.Context, name string) error
+}
+
+// ErrNotFound is returned by Get when name has no entry in the keyring.
+var ErrNotFound = fmt.Errorf("keyring: not found")
+
+// New returns a Keyring backed by the current platform's native secret
+// store.
+func New() Keyring {
+	return newPlatformKeyring()
+}
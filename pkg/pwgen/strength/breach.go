@@ -0,0 +1,48 @@
+package strength
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// hibpRangeURL is the Have I Been Pwned k-anonymity range endpoint: only
+// the first 5 characters of the password's SHA-1 hash are ever sent.
+const hibpRangeURL = "https://api.pwnedpasswords.com/range/"
+
+// CheckBreached queries the HIBP range API for the given password and
+// returns how many times it has been seen in known breaches (0 if none).
+// ctx bounds the request, so a slow or hanging HIBP response doesn't block
+// an interactive caller (e.g. the create wizard) indefinitely.
+func CheckBreached(ctx context.Context, password string) (int, error) {
+	sum := fmt.Sprintf("%X", sha1.Sum([]byte(password)))
+	prefix, suffix := sum[:5], sum[5:]
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, hibpRangeURL+prefix, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("HIBP range API returned %s", resp.Status)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), ":", 2)
+		if len(parts) != 2 || parts[0] != suffix {
+			continue
+		}
+		return strconv.Atoi(strings.TrimSpace(parts[1]))
+	}
+	return 0, scanner.Err()
+}
@@ -0,0 +1,124 @@
+// Package strength estimates password strength. It is not a full zxcvbn
+// port - no dictionary or pattern database - but approximates its output
+// shape (an entropy figure, a 0-4 grade and a crack-time estimate) using
+// character-set size with a penalty for low character diversity, which is
+// enough to catch the obviously weak passwords this package is meant to
+// flag during interactive entry.
+package strength
+
+import (
+	"fmt"
+	"math"
+)
+
+// Score bundles strength results for display.
+type Score struct {
+	// Entropy is the estimated entropy in bits.
+	Entropy float64
+	// Grade is a 0 (very weak) to 4 (very strong) rating.
+	Grade int
+	// CrackTime is a human-readable estimate of how long an offline
+	// attacker guessing 10 billion passwords/second would take.
+	CrackTime string
+}
+
+// guessesPerSecond models a determined offline attacker with GPU/ASIC
+// hardware, as commonly assumed by zxcvbn-style estimators.
+const guessesPerSecond = 1e10
+
+// Estimate scores the given password.
+func Estimate(password string) Score {
+	entropy := entropyBits(password)
+	seconds := math.Pow(2, entropy) / guessesPerSecond
+
+	return Score{
+		Entropy:   entropy,
+		Grade:     grade(entropy),
+		CrackTime: humanizeDuration(seconds),
+	}
+}
+
+// entropyBits estimates the entropy of password in bits from the size of
+// the character classes it draws from, penalized by how little of the
+// password is actually unique characters (catches "aaaaaaaa", "12121212"
+// and similar low-diversity passwords that a pure character-class measure
+// would otherwise rate highly).
+func entropyBits(password string) float64 {
+	if password == "" {
+		return 0
+	}
+
+	var hasLower, hasUpper, hasDigit, hasSymbol bool
+	seen := map[rune]struct{}{}
+	for _, r := range password {
+		switch {
+		case r >= 'a' && r <= 'z':
+			hasLower = true
+		case r >= 'A' && r <= 'Z':
+			hasUpper = true
+		case r >= '0' && r <= '9':
+			hasDigit = true
+		default:
+			hasSymbol = true
+		}
+		seen[r] = struct{}{}
+	}
+
+	pool := 0
+	if hasLower {
+		pool += 26
+	}
+	if hasUpper {
+		pool += 26
+	}
+	if hasDigit {
+		pool += 10
+	}
+	if hasSymbol {
+		pool += 33
+	}
+	if pool == 0 {
+		pool = 1
+	}
+
+	bits := float64(len(password)) * math.Log2(float64(pool))
+	diversity := float64(len(seen)) / float64(len(password))
+
+	return bits * diversity
+}
+
+func grade(entropy float64) int {
+	switch {
+	case entropy < 28:
+		return 0
+	case entropy < 36:
+		return 1
+	case entropy < 60:
+		return 2
+	case entropy < 80:
+		return 3
+	default:
+		return 4
+	}
+}
+
+func humanizeDuration(seconds float64) string {
+	switch {
+	case seconds < 1:
+		return "instantly"
+	case seconds < 60:
+		return fmt.Sprintf("%.0f seconds", seconds)
+	case seconds < 3600:
+		return fmt.Sprintf("%.0f minutes", seconds/60)
+	case seconds < 86400:
+		return fmt.Sprintf("%.0f hours", seconds/3600)
+	case seconds < 365*86400:
+		return fmt.Sprintf("%.0f days", seconds/86400)
+	default:
+		years := seconds / (365 * 86400)
+		if years > 1e6 {
+			return "centuries"
+		}
+		return fmt.Sprintf("%.0f years", years)
+	}
+}
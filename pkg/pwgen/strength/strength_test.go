@@ -0,0 +1,39 @@
+package strength
+
+import "testing"
+
+func TestEstimate(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		password string
+		minGrade int
+		maxGrade int
+	}{
+		{"empty", "", 0, 0},
+		{"low diversity", "aaaaaaaaaaaa", 0, 0},
+		{"short digits only", "123456", 0, 1},
+		{"long passphrase", "correct horse battery staple generator", 3, 4},
+		{"long random mixed", "xQ2!vR9#mK4$pL7@nB1%", 3, 4},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			score := Estimate(tc.password)
+			if score.Grade < tc.minGrade || score.Grade > tc.maxGrade {
+				t.Errorf("Estimate(%q).Grade = %d, want between %d and %d", tc.password, score.Grade, tc.minGrade, tc.maxGrade)
+			}
+			if score.CrackTime == "" {
+				t.Errorf("Estimate(%q).CrackTime is empty", tc.password)
+			}
+		})
+	}
+}
+
+func TestEstimateMonotonic(t *testing.T) {
+	weak := Estimate("abc")
+	strong := Estimate("abcDEF123!@#xyzUVW456$%^")
+	if strong.Entropy <= weak.Entropy {
+		t.Errorf("expected longer, more diverse password to have higher entropy: got %f <= %f", strong.Entropy, weak.Entropy)
+	}
+	if strong.Grade < weak.Grade {
+		t.Errorf("expected longer, more diverse password to have a grade >= the weak one: got %d < %d", strong.Grade, weak.Grade)
+	}
+}